@@ -1,19 +1,21 @@
-// Package blog is a simple blog based on Gorilla mux and blackfriday markdown.
+// Package blog is a simple blog based on Gorilla mux and Goldmark markdown.
 package blog
 
 import (
+	"bytes"
 	"fmt"
 	"html/template"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
-	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/gorilla/mux"
-	"github.com/russross/blackfriday"
 )
 
 const (
@@ -27,6 +29,8 @@ const (
 	defPostDirFmt = "../%s/"
 	// defPageSize is the default number of posts to a page.
 	defPageSize = 5
+	// defFeedSize is the default number of posts to include in a feed.
+	defFeedSize = 10
 )
 
 // Blog is a blog site Handler..
@@ -39,65 +43,181 @@ type Blog struct {
 	tplPath string
 	// PostDir is the relative path to the directory containing blog posts.
 	postDir string
+	// staticDir is an optional directory watched for changes in Development
+	// mode, for static assets (CSS, images, ...) served outside of Blog
+	// itself. Set via WithStaticDir; unset by default.
+	staticDir string
 	// PageSize is the number of posts to a page.
 	pageSize int
+	// Development enables developer-only behavior, such as including
+	// draft posts that would otherwise be hidden.
+	Development bool
+	// FeedSize is the number of posts to include in the Atom/RSS feeds.
+	FeedSize int
+	// NotFoundTplPath is the path to the template rendered for 404
+	// responses, such as an unknown post permalink.
+	NotFoundTplPath string
+	// ErrorTplPath is the path to the template rendered for 500 responses.
+	ErrorTplPath string
+	// Logger receives internal error reports. Defaults to log.Default().
+	Logger Logger
+	// renderer converts post Markdown into HTML.
+	renderer Renderer
+	// cache holds parsed posts, kept fresh by watcher.
+	cache *postCache
+	// tplCache holds the parsed template, kept fresh by watcher when
+	// Development is set.
+	tplCache *templateCache
+	// watcher notifies of changes under postDir and to tplPath.
+	watcher *fsnotify.Watcher
+	// liveReload fans out reload notifications to /_livereload clients in
+	// Development mode.
+	liveReload *liveReload
 }
 
 // NewBlogSimple is a shorthand for NewBlog with default arguments.
-func NewBlogSimple(name string, router *mux.Router) *Blog {
+func NewBlogSimple(name string, router *mux.Router, opts ...Option) *Blog {
 	return NewBlog(name, router, fmt.Sprintf(defTplPathFmt, name),
-		fmt.Sprintf(defPostDirFmt, name), defPageSize)
+		fmt.Sprintf(defPostDirFmt, name), defPageSize, opts...)
 }
 
 // NewBlog returns a new Blog instance.
 func NewBlog(name string, router *mux.Router, tplPath, postDir string,
-	pageSize int) (blog *Blog) {
+	pageSize int, opts ...Option) (blog *Blog) {
 
 	blog = &Blog{name: name, router: router, tplPath: tplPath,
-		postDir: postDir, pageSize: pageSize}
-	// Hook up paths for the main blog and post permalinks.
-	router.Handle("/", blog)
-	router.Handle("/post/{year:[0-9]+}/{month:[0-9]+}/{day:[0-9]+}/{name}/",
-		blog).Name(name + "-post")
-	return
-}
-
-func (self Blog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+		postDir: postDir, pageSize: pageSize, FeedSize: defFeedSize,
+		NotFoundTplPath: defNotFoundTplPath, ErrorTplPath: defErrorTplPath,
+		Logger: log.Default(), renderer: newGoldmarkRenderer(),
+		cache: newPostCache(), liveReload: newLiveReload()}
+	for _, opt := range opts {
+		opt(blog)
+	}
 
-	tpl, err := template.ParseFiles(self.tplPath)
+	tplCache, err := newTemplateCache(blog.tplPath)
 	if err != nil {
 		log.Fatal(err)
 	}
+	blog.tplCache = tplCache
+
+	// Hook up paths for the main blog and post permalinks before loading any
+	// posts: newPost resolves a post's permalink via the "-post" named
+	// route, so that route must already be registered by the time
+	// loadPosts runs.
+	router.Handle("/", blog.withErrorHandling(blog.serve))
+	router.HandleFunc("/post/{year:[0-9]+}/{month:[0-9]+}/{day:[0-9]+}/{name}/",
+		blog.withErrorHandling(blog.serve)).Name(name + "-post")
+	router.HandleFunc("/page/{n:[0-9]+}/",
+		blog.withErrorHandling(blog.serve)).Name(name + "-page")
+	router.HandleFunc("/tag/{tag}/",
+		blog.withErrorHandling(blog.serve)).Name(name + "-tag")
+	router.HandleFunc("/tag/{tag}/page/{n:[0-9]+}/",
+		blog.withErrorHandling(blog.serve)).Name(name + "-tag-page")
+	router.HandleFunc("/tags/",
+		blog.withErrorHandling(blog.serve)).Name(name + "-tags")
+	router.HandleFunc("/feed.atom", blog.serveFeed(feedFormatAtom)).
+		Name(name + "-feed-atom")
+	router.HandleFunc("/feed.rss", blog.serveFeed(feedFormatRSS)).
+		Name(name + "-feed-rss")
+	router.HandleFunc("/_livereload", blog.serveLiveReload).
+		Name(name + "-livereload")
+
+	if err := blog.loadPosts(); err != nil {
+		log.Fatal(err)
+	}
+	if err := blog.watch(); err != nil {
+		log.Fatal(err)
+	}
+	return
+}
+
+// serve renders the page matching the current route. Any error it returns
+// is translated into an HTTP response by withErrorHandling.
+func (self *Blog) serve(w http.ResponseWriter, r *http.Request) error {
+
+	tpl := self.tplCache.get()
 
 	// data is the template data for the Blog.
 	data := struct {
 		// Posts is the slice of posts for this blog page.
 		Posts []post
+		// Tags is the list of known tags, for rendering tag listings.
+		Tags []string
+		// CurrentTag is the tag being viewed, when on a tag page.
+		CurrentTag string
 	}{}
 
 	// Pull out {…} variables from muxer.
 	vars := mux.Vars(r)
 
+	var err error
 	switch mux.CurrentRoute(r).GetName() {
 	case self.name + "-post":
 		data.Posts = make([]post, 1)
 		newPost, err := self.getPost(vars["year"], vars["month"],
 			vars["day"], vars["name"])
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		data.Posts[0] = *newPost
+	case self.name + "-tag":
+		data.CurrentTag = vars["tag"]
+		data.Posts, err = self.getTagPage(data.CurrentTag, 0)
+	case self.name + "-tag-page":
+		data.CurrentTag = vars["tag"]
+		var page int
+		page, err = strconv.Atoi(vars["n"])
+		if err == nil {
+			data.Posts, err = self.getTagPage(data.CurrentTag, page)
+		}
+	case self.name + "-tags":
+		for tag := range self.TagCloud() {
+			data.Tags = append(data.Tags, tag)
+		}
+		sort.Strings(data.Tags)
+	case self.name + "-page":
+		var page int
+		page, err = strconv.Atoi(vars["n"])
+		if err == nil {
+			data.Posts, err = self.getPage(page)
+		}
 	default:
 		data.Posts, err = self.getPage(0)
 	}
+	if err != nil {
+		return err
+	}
+
+	var rendered bytes.Buffer
+	if err := tpl.Execute(&rendered, data); err != nil {
+		return err
+	}
 
-	tpl.Execute(w, data)
+	out := rendered.Bytes()
+	if self.Development {
+		out = injectLiveReload(out)
+	}
+	w.Write(out)
+	return nil
 }
 
 // post is a single blog entry.
 type post struct {
-	// Name is the name/title of the post.
+	// Name is the name/title of the post as derived from its filename.
 	Name string
+	// Title is the post's display title, overriding Name when set via
+	// front matter.
+	Title string
+	// Description is a short summary of the post, set via front matter.
+	Description string
+	// Tags are the categories this post belongs to, set via front matter.
+	Tags []string
+	// Draft marks a post as hidden from getPage outside of development mode.
+	Draft bool
+	// Author is the name of the post's author, set via front matter.
+	Author string
+	// Params holds arbitrary front-matter fields not otherwise recognized.
+	Params map[string]interface{}
 	// Body is the content of the post.
 	Body template.HTML
 	// Date is the date the post was published.
@@ -107,7 +227,7 @@ type post struct {
 }
 
 // newPost returns a new post instance.
-func (self Blog) newPost(path string, info os.FileInfo) (*post, error) {
+func (self *Blog) newPost(path string, info os.FileInfo) (*post, error) {
 
 	// Extract the fields from the filename, assuming a format of:
 	//  YYYY-MM-DD.NAME.md
@@ -148,59 +268,71 @@ func (self Blog) newPost(path string, info os.FileInfo) (*post, error) {
 	if err != nil {
 		return nil, err
 	}
-	postHTML := template.HTML(blackfriday.MarkdownCommon(postMarkdown))
-
-	return &post{Name: postName, Body: postHTML, Date: postDate,
-		Permalink: postPermalink}, nil
-}
 
-// getPost retrieves a post from the given identifying information.
-func (self Blog) getPost(year, month, day, name string) (*post, error) {
-	// Use permalink info to construct file path.
-	path := self.postDir + fmt.Sprintf("%04s-%02s-%02s.%s.md", year, month, day, name)
-	// Make sure the file exists and get info.
-	info, err := os.Stat(path)
+	fm, postMarkdown, err := parseFrontMatter(postMarkdown)
 	if err != nil {
 		return nil, err
 	}
-	newPost, err := self.newPost(path, info)
+	postHTML, err := self.renderer.Render(postMarkdown)
 	if err != nil {
 		return nil, err
 	}
-	return newPost, nil
+
+	postTitle := fm.Title
+	if postTitle == "" {
+		postTitle = postName
+	}
+
+	return &post{Name: postName, Title: postTitle, Description: fm.Description,
+		Tags: fm.Tags, Draft: fm.Draft, Author: fm.Author, Params: fm.Params,
+		Body: postHTML, Date: postDate, Permalink: postPermalink}, nil
 }
 
-// getPage retrieves one page of posts.
-// XXX: pagination not actually implemented yet; page parameter ignored.
-func (self Blog) getPage(page int) ([]post, error) {
+// getPost retrieves a post from the given identifying information.
+func (self *Blog) getPost(year, month, day, name string) (*post, error) {
+	// Use permalink info to construct file path.
+	path := self.postDir + fmt.Sprintf("%04s-%02s-%02s.%s.md", year, month, day, name)
+	newPost, ok := self.cache.get(path)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return &newPost, nil
+}
+
+// allPosts returns every cached, non-draft post (or every cached post, if
+// Development is set), most recent first.
+func (self *Blog) allPosts() ([]post, error) {
 	var posts []post
-	// A function to walk the post directory and put together our slice.
-	buildPosts := func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			// Don't die, just note problem and move on.
-			log.Printf("Error walking posts: %v\n", err)
-			return nil
+	for _, p := range self.cache.all() {
+		if p.Draft && !self.Development {
+			continue
 		}
+		posts = append(posts, p)
+	}
 
-		if !info.IsDir() {
-			newPost, err := self.newPost(path, info)
-			if err != nil {
-				return err
-			}
-			posts = append(posts, *newPost)
-		}
+	// Sort by date, most recent first.
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].Date.After(posts[j].Date)
+	})
 
-		return nil
-	}
-	err := filepath.Walk(self.postDir, buildPosts)
+	return posts, nil
+}
+
+// getPage retrieves one page of posts, most recent first. Pages are
+// numbered from 0.
+func (self *Blog) getPage(page int) ([]post, error) {
+	posts, err := self.allPosts()
 	if err != nil {
 		return nil, err
 	}
 
-	// Reverse post order so most recent is shown first.
-	for i, j := 0, len(posts)-1; i < j; i, j = i+1, j-1 {
-		posts[i], posts[j] = posts[j], posts[i]
+	start := page * self.pageSize
+	if start >= len(posts) {
+		return nil, nil
 	}
-
-	return posts, nil
+	end := start + self.pageSize
+	if end > len(posts) {
+		end = len(posts)
+	}
+	return posts[start:end], nil
 }
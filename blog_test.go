@@ -0,0 +1,62 @@
+package blog
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// newTestBlog sets up a Blog backed by temp directories containing a
+// minimal template and a single post, mirroring a real postDir with
+// content.
+func newTestBlog(t *testing.T) (*Blog, *mux.Router) {
+	t.Helper()
+
+	tplDir := t.TempDir()
+	tplPath := filepath.Join(tplDir, "blog.tpl")
+	if err := os.WriteFile(tplPath, []byte("<html><body></body></html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	postDir := t.TempDir()
+	postPath := filepath.Join(postDir, "2024-01-02.hello.md")
+	if err := os.WriteFile(postPath, []byte("# Hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	blog := NewBlog("test", router, tplPath, postDir+string(os.PathSeparator), defPageSize)
+	return blog, router
+}
+
+// TestNewBlogLoadsExistingPosts guards against a regression where NewBlog
+// called loadPosts before registering the "-post" named route: newPost
+// resolves a post's permalink via that route, so loading a postDir with
+// content during construction used to panic with a nil pointer
+// dereference in mux.Route.URL.
+func TestNewBlogLoadsExistingPosts(t *testing.T) {
+	blog, _ := newTestBlog(t)
+
+	post, err := blog.getPost("2024", "01", "02", "hello")
+	if err != nil {
+		t.Fatalf("getPost: %v", err)
+	}
+	if want := "/post/2024/1/2/hello/"; post.Permalink != want {
+		t.Errorf("Permalink = %q, want %q", post.Permalink, want)
+	}
+}
+
+func TestServeIndexDoesNotPanic(t *testing.T) {
+	_, router := newTestBlog(t)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	router.ServeHTTP(w, r)
+
+	if w.Code != 200 {
+		t.Errorf("status = %d, want 200", w.Code)
+	}
+}
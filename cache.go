@@ -0,0 +1,220 @@
+package blog
+
+import (
+	"crypto/sha256"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// postCache holds parsed posts keyed by file path, along with a hash of the
+// file each was parsed from, so unchanged files can be skipped on reload. It
+// also maintains a tag index, lazily rebuilt the next time it is read after
+// the post set changes, so tag routes and the tag cloud can serve from it
+// instead of walking every post on each request. The rebuild is deferred
+// rather than done inline in set/remove so that loading many posts in a row
+// (e.g. loadPosts' initial walk) pays for one rebuild instead of one per
+// post.
+type postCache struct {
+	mu    sync.RWMutex
+	posts map[string]cachedPost
+
+	// tagIndex maps tag name to its posts, most recent first, including
+	// drafts. publishedTagIndex is the same, excluding drafts. tagIndexDirty
+	// is set whenever posts changes and cleared once the indexes are
+	// rebuilt to match.
+	tagIndex          map[string][]post
+	publishedTagIndex map[string][]post
+	tagIndexDirty     bool
+}
+
+// cachedPost pairs a parsed post with the hash of the file it was read from.
+type cachedPost struct {
+	post post
+	hash [sha256.Size]byte
+}
+
+// newPostCache returns an empty postCache.
+func newPostCache() *postCache {
+	return &postCache{
+		posts:             map[string]cachedPost{},
+		tagIndex:          map[string][]post{},
+		publishedTagIndex: map[string][]post{},
+	}
+}
+
+// all returns every cached post, in no particular order.
+func (self *postCache) all() []post {
+	self.mu.RLock()
+	defer self.mu.RUnlock()
+
+	posts := make([]post, 0, len(self.posts))
+	for _, cached := range self.posts {
+		posts = append(posts, cached.post)
+	}
+	return posts
+}
+
+// get returns the cached post for path, if any.
+func (self *postCache) get(path string) (post, bool) {
+	self.mu.RLock()
+	defer self.mu.RUnlock()
+
+	cached, ok := self.posts[path]
+	return cached.post, ok
+}
+
+// hashMatches reports whether path is already cached under hash.
+func (self *postCache) hashMatches(path string, hash [sha256.Size]byte) bool {
+	self.mu.RLock()
+	defer self.mu.RUnlock()
+
+	cached, ok := self.posts[path]
+	return ok && cached.hash == hash
+}
+
+// set stores newPost for path under the given content hash.
+func (self *postCache) set(path string, hash [sha256.Size]byte, newPost post) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.posts[path] = cachedPost{post: newPost, hash: hash}
+	self.tagIndexDirty = true
+}
+
+// remove deletes path from the cache.
+func (self *postCache) remove(path string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	delete(self.posts, path)
+	self.tagIndexDirty = true
+}
+
+// rebuildTagIndex recomputes tagIndex and publishedTagIndex from posts. The
+// caller must hold mu for writing.
+func (self *postCache) rebuildTagIndex() {
+	posts := make([]post, 0, len(self.posts))
+	for _, cached := range self.posts {
+		posts = append(posts, cached.post)
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].Date.After(posts[j].Date)
+	})
+
+	all := map[string][]post{}
+	published := map[string][]post{}
+	for _, p := range posts {
+		for _, tag := range p.Tags {
+			all[tag] = append(all[tag], p)
+			if !p.Draft {
+				published[tag] = append(published[tag], p)
+			}
+		}
+	}
+	self.tagIndex = all
+	self.publishedTagIndex = published
+	self.tagIndexDirty = false
+}
+
+// tags returns the cached tag index: every tag's posts, most recent first,
+// including drafts when development is true. It rebuilds the index first if
+// posts has changed since the last rebuild.
+func (self *postCache) tags(development bool) map[string][]post {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.tagIndexDirty {
+		self.rebuildTagIndex()
+	}
+	if development {
+		return self.tagIndex
+	}
+	return self.publishedTagIndex
+}
+
+// loadPost parses path into the post cache, skipping the parse if path's
+// content hash hasn't changed since it was last cached.
+func (self *Blog) loadPost(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(content)
+	if self.cache.hashMatches(path, hash) {
+		return nil
+	}
+
+	newPost, err := self.newPost(path, info)
+	if err != nil {
+		return err
+	}
+	self.cache.set(path, hash, *newPost)
+	return nil
+}
+
+// loadPosts walks postDir, loading or refreshing every post into the cache.
+func (self *Blog) loadPosts() error {
+	return filepath.Walk(self.postDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Don't die, just note problem and move on.
+			self.Logger.Printf("Error walking posts: %v\n", err)
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if err := self.loadPost(path); err != nil {
+			self.Logger.Printf("Error loading post %s: %v\n", path, err)
+		}
+		return nil
+	})
+}
+
+// templateCache holds the Blog's parsed template, reparsing it from disk on
+// reload rather than on every request.
+type templateCache struct {
+	mu   sync.RWMutex
+	tpl  *template.Template
+	path string
+}
+
+// newTemplateCache parses path and returns a templateCache wrapping it.
+func newTemplateCache(path string) (*templateCache, error) {
+	tpl, err := template.ParseFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	return &templateCache{tpl: tpl, path: path}, nil
+}
+
+// get returns the cached template.
+func (self *templateCache) get() *template.Template {
+	self.mu.RLock()
+	defer self.mu.RUnlock()
+	return self.tpl
+}
+
+// reload reparses the template from disk.
+func (self *templateCache) reload() error {
+	tpl, err := template.ParseFiles(self.path)
+	if err != nil {
+		return err
+	}
+
+	self.mu.Lock()
+	self.tpl = tpl
+	self.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,95 @@
+package blog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func fakeWriteEvent(name string) fsnotify.Event {
+	return fsnotify.Event{Name: name, Op: fsnotify.Write}
+}
+
+func fakeRemoveEvent(name string) fsnotify.Event {
+	return fsnotify.Event{Name: name, Op: fsnotify.Remove}
+}
+
+// TestLoadPostSkipsUnchangedContent verifies that reloading an unchanged post
+// file leaves its cached post as it was, and that a change to the file's
+// content is picked up on the next load.
+func TestLoadPostSkipsUnchangedContent(t *testing.T) {
+	blog, _ := newTestBlog(t)
+	path := filepath.Join(blog.postDir, "2024-01-02.hello.md")
+
+	before, ok := blog.cache.get(path)
+	if !ok {
+		t.Fatalf("post %s not cached after NewBlog", path)
+	}
+
+	if err := blog.loadPost(path); err != nil {
+		t.Fatalf("loadPost (unchanged): %v", err)
+	}
+	after, ok := blog.cache.get(path)
+	if !ok {
+		t.Fatalf("post %s missing from cache after reload", path)
+	}
+	if before.Body != after.Body || before.Date != after.Date {
+		t.Errorf("unchanged content produced a different cached post: %+v != %+v", before, after)
+	}
+
+	// Changing the content should be picked up on the next load.
+	if err := os.WriteFile(path, []byte("# Hello\n\nedited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := blog.loadPost(path); err != nil {
+		t.Fatalf("loadPost (changed): %v", err)
+	}
+	edited, ok := blog.cache.get(path)
+	if !ok {
+		t.Fatalf("post %s missing from cache after edit", path)
+	}
+	if edited.Body == before.Body {
+		t.Error("editing the post file did not change its cached, rendered Body")
+	}
+}
+
+// TestHandleWatchEventUpdatesCache exercises the watcher's event handling
+// directly: a write/create event reloads the post, a remove/rename event
+// drops it from the cache.
+func TestHandleWatchEventUpdatesCache(t *testing.T) {
+	blog, _ := newTestBlog(t)
+	path := filepath.Join(blog.postDir, "2024-03-04.second.md")
+	if err := os.WriteFile(path, []byte("# Second\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	blog.handleWatchEvent(fakeWriteEvent(path))
+	if _, ok := blog.cache.get(path); !ok {
+		t.Fatalf("post %s not cached after a write event", path)
+	}
+
+	blog.handleWatchEvent(fakeRemoveEvent(path))
+	if _, ok := blog.cache.get(path); ok {
+		t.Errorf("post %s still cached after a remove event", path)
+	}
+}
+
+func TestWatchPicksUpNewPostWithinTimeout(t *testing.T) {
+	blog, _ := newTestBlog(t)
+	path := filepath.Join(blog.postDir, "2024-05-06.third.md")
+	if err := os.WriteFile(path, []byte("# Third\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := blog.cache.get(path); ok {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("post %s not picked up by the filesystem watcher within timeout", path)
+}
@@ -0,0 +1,60 @@
+package blog
+
+import (
+	"html/template"
+	"net/http"
+	"os"
+)
+
+const (
+	// defNotFoundTplPath is the default path to the 404 error page template.
+	defNotFoundTplPath = "../template/404.tpl"
+	// defErrorTplPath is the default path to the 500 error page template.
+	defErrorTplPath = "../template/500.tpl"
+)
+
+// Logger is the logging interface Blog uses to report internal errors.
+// *log.Logger satisfies it directly; a *slog.Logger can be adapted with
+// slog.NewLogLogger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// handlerFunc is like http.HandlerFunc, but may return an error instead of
+// writing a response directly, letting Blog centralize how errors become
+// HTTP responses.
+type handlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// withErrorHandling adapts fn into an http.HandlerFunc, translating any
+// error it returns into an HTTP response via handleError instead of
+// crashing the process.
+func (self *Blog) withErrorHandling(fn handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(w, r); err != nil {
+			self.handleError(w, r, err)
+		}
+	}
+}
+
+// handleError maps err to an HTTP status code and renders the matching
+// error page template, falling back to a plain text response if no such
+// template exists.
+func (self *Blog) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	tplPath := self.ErrorTplPath
+	if os.IsNotExist(err) {
+		status = http.StatusNotFound
+		tplPath = self.NotFoundTplPath
+	}
+
+	self.Logger.Printf("Error serving %s: %v\n", r.URL.Path, err)
+
+	tpl, tplErr := template.ParseFiles(tplPath)
+	if tplErr != nil {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	w.WriteHeader(status)
+	tpl.Execute(w, nil)
+}
@@ -0,0 +1,75 @@
+package blog
+
+import (
+	"net/http"
+
+	"github.com/gorilla/feeds"
+)
+
+// feedFormat selects which syndication format serveFeed renders.
+type feedFormat int
+
+const (
+	// feedFormatAtom renders an Atom 1.0 document.
+	feedFormatAtom feedFormat = iota
+	// feedFormatRSS renders an RSS 2.0 document.
+	feedFormatRSS
+)
+
+// feed builds a *feeds.Feed from the Blog's most recent FeedSize posts.
+func (self *Blog) feed() (*feeds.Feed, error) {
+	posts, err := self.allPosts()
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) > self.FeedSize {
+		posts = posts[:self.FeedSize]
+	}
+
+	feed := &feeds.Feed{
+		Title: self.name,
+		Link:  &feeds.Link{Href: "/"},
+	}
+	if len(posts) > 0 {
+		feed.Updated = posts[0].Date
+	}
+
+	for _, p := range posts {
+		title := p.Title
+		if title == "" {
+			title = p.Name
+		}
+		feed.Add(&feeds.Item{
+			Title:       title,
+			Link:        &feeds.Link{Href: p.Permalink},
+			Id:          p.Permalink,
+			Description: p.Description,
+			Author:      &feeds.Author{Name: p.Author},
+			Created:     p.Date,
+			Content:     string(p.Body),
+		})
+	}
+
+	return feed, nil
+}
+
+// serveFeed returns an http.HandlerFunc rendering the Blog's post index as
+// an Atom or RSS feed, depending on format.
+func (self *Blog) serveFeed(format feedFormat) http.HandlerFunc {
+	return self.withErrorHandling(func(w http.ResponseWriter, r *http.Request) error {
+		feed, err := self.feed()
+		if err != nil {
+			return err
+		}
+
+		switch format {
+		case feedFormatAtom:
+			w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+			return feed.WriteAtom(w)
+		case feedFormatRSS:
+			w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+			return feed.WriteRss(w)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,32 @@
+package blog
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+)
+
+// TestFeedNotLimitedByPageSize guards against a regression where feed
+// built its item list from getPage(0), which truncates to pageSize before
+// feed ever sees the posts. With the common case of FeedSize > pageSize,
+// that made FeedSize effectively dead: the feed could never contain more
+// items than a single page.
+func TestFeedNotLimitedByPageSize(t *testing.T) {
+	cache := newPostCache()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	const total = 8
+	for i := 0; i < total; i++ {
+		p := post{Name: "post", Date: base.AddDate(0, 0, i)}
+		cache.set(string(rune('a'+i)), sha256.Sum256([]byte{byte(i)}), p)
+	}
+
+	blog := &Blog{name: "test", pageSize: 3, FeedSize: 5, cache: cache}
+
+	feed, err := blog.feed()
+	if err != nil {
+		t.Fatalf("feed: %v", err)
+	}
+	if got, want := len(feed.Items), blog.FeedSize; got != want {
+		t.Errorf("len(feed.Items) = %d, want %d (pageSize = %d)", got, want, blog.pageSize)
+	}
+}
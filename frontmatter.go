@@ -0,0 +1,117 @@
+package blog
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatter holds the metadata a post may declare ahead of its Markdown
+// body, set off by a pair of `---` (YAML) or `+++` (TOML) fence lines.
+type frontMatter struct {
+	// Title overrides the filename-derived post title.
+	Title string `yaml:"title" toml:"title"`
+	// Description is a short summary, typically used in feeds and listings.
+	Description string `yaml:"description" toml:"description"`
+	// Tags are the categories this post belongs to.
+	Tags []string `yaml:"tags" toml:"tags"`
+	// Draft hides the post from getPage unless the Blog is in development mode.
+	Draft bool `yaml:"draft" toml:"draft"`
+	// Author is the name of the post's author.
+	Author string `yaml:"author" toml:"author"`
+	// Params holds any front-matter keys not otherwise recognized above.
+	Params map[string]interface{} `yaml:"-" toml:"-"`
+}
+
+// yamlFence and tomlFence are the fence lines delimiting a front-matter block.
+const (
+	yamlFence = "---"
+	tomlFence = "+++"
+)
+
+// knownFrontMatterKeys are excluded from Params since they already have a
+// dedicated field on frontMatter.
+var knownFrontMatterKeys = map[string]bool{
+	"title":       true,
+	"description": true,
+	"tags":        true,
+	"draft":       true,
+	"author":      true,
+}
+
+// splitFrontMatter splits raw into a front-matter block (if any) and the
+// remaining body. If raw does not begin with a recognized fence, the block
+// is empty and body is raw unchanged.
+func splitFrontMatter(raw []byte) (fence string, block, body []byte) {
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	if !scanner.Scan() {
+		return "", nil, raw
+	}
+	first := strings.TrimRight(scanner.Text(), "\r")
+	if first != yamlFence && first != tomlFence {
+		return "", nil, raw
+	}
+	fence = first
+
+	var blockBuf, bodyBuf bytes.Buffer
+	closed := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !closed && strings.TrimRight(line, "\r") == fence {
+			closed = true
+			continue
+		}
+		if closed {
+			bodyBuf.WriteString(line)
+			bodyBuf.WriteByte('\n')
+		} else {
+			blockBuf.WriteString(line)
+			blockBuf.WriteByte('\n')
+		}
+	}
+	if !closed {
+		// Unterminated fence; treat the whole file as body.
+		return "", nil, raw
+	}
+	return fence, blockBuf.Bytes(), bodyBuf.Bytes()
+}
+
+// parseFrontMatter extracts and parses a post's front matter, returning the
+// remaining Markdown body. Posts without a front-matter block return a zero
+// frontMatter and the original content as body.
+func parseFrontMatter(raw []byte) (fm frontMatter, body []byte, err error) {
+	fence, block, body := splitFrontMatter(raw)
+	if fence == "" {
+		return frontMatter{}, body, nil
+	}
+
+	rawFields := map[string]interface{}{}
+	switch fence {
+	case yamlFence:
+		if err := yaml.Unmarshal(block, &fm); err != nil {
+			return frontMatter{}, nil, err
+		}
+		if err := yaml.Unmarshal(block, &rawFields); err != nil {
+			return frontMatter{}, nil, err
+		}
+	case tomlFence:
+		if _, err := toml.Decode(string(block), &fm); err != nil {
+			return frontMatter{}, nil, err
+		}
+		if _, err := toml.Decode(string(block), &rawFields); err != nil {
+			return frontMatter{}, nil, err
+		}
+	}
+
+	fm.Params = map[string]interface{}{}
+	for k, v := range rawFields {
+		if !knownFrontMatterKeys[k] {
+			fm.Params[k] = v
+		}
+	}
+
+	return fm, body, nil
+}
@@ -0,0 +1,90 @@
+package blog
+
+import "testing"
+
+func TestParseFrontMatterYAML(t *testing.T) {
+	raw := []byte("---\n" +
+		"title: Hello\n" +
+		"tags: [a, b]\n" +
+		"draft: true\n" +
+		"author: Jane\n" +
+		"description: A test post\n" +
+		"extra: value\n" +
+		"---\n" +
+		"# Body\n")
+
+	fm, body, err := parseFrontMatter(raw)
+	if err != nil {
+		t.Fatalf("parseFrontMatter: %v", err)
+	}
+	if fm.Title != "Hello" {
+		t.Errorf("Title = %q, want %q", fm.Title, "Hello")
+	}
+	if fm.Author != "Jane" {
+		t.Errorf("Author = %q, want %q", fm.Author, "Jane")
+	}
+	if !fm.Draft {
+		t.Error("Draft = false, want true")
+	}
+	if got, want := len(fm.Tags), 2; got != want {
+		t.Errorf("len(Tags) = %d, want %d", got, want)
+	}
+	if fm.Params["extra"] != "value" {
+		t.Errorf(`Params["extra"] = %v, want "value"`, fm.Params["extra"])
+	}
+	if _, ok := fm.Params["title"]; ok {
+		t.Error(`Params contains "title", want it excluded as a known field`)
+	}
+	if string(body) != "# Body\n" {
+		t.Errorf("body = %q, want %q", body, "# Body\n")
+	}
+}
+
+func TestParseFrontMatterTOML(t *testing.T) {
+	raw := []byte("+++\n" +
+		"title = \"Hello\"\n" +
+		"tags = [\"a\", \"b\"]\n" +
+		"+++\n" +
+		"body text\n")
+
+	fm, body, err := parseFrontMatter(raw)
+	if err != nil {
+		t.Fatalf("parseFrontMatter: %v", err)
+	}
+	if fm.Title != "Hello" {
+		t.Errorf("Title = %q, want %q", fm.Title, "Hello")
+	}
+	if string(body) != "body text\n" {
+		t.Errorf("body = %q, want %q", body, "body text\n")
+	}
+}
+
+func TestParseFrontMatterNone(t *testing.T) {
+	raw := []byte("# Just a post\nNo front matter here.\n")
+
+	fm, body, err := parseFrontMatter(raw)
+	if err != nil {
+		t.Fatalf("parseFrontMatter: %v", err)
+	}
+	if fm.Title != "" || fm.Tags != nil || fm.Params != nil {
+		t.Errorf("fm = %+v, want zero value", fm)
+	}
+	if string(body) != string(raw) {
+		t.Errorf("body = %q, want input unchanged", body)
+	}
+}
+
+func TestParseFrontMatterUnterminatedFence(t *testing.T) {
+	raw := []byte("---\ntitle: Hello\nno closing fence\n")
+
+	fm, body, err := parseFrontMatter(raw)
+	if err != nil {
+		t.Fatalf("parseFrontMatter: %v", err)
+	}
+	if fm.Title != "" || fm.Tags != nil || fm.Params != nil {
+		t.Errorf("fm = %+v, want zero value for unterminated fence", fm)
+	}
+	if string(body) != string(raw) {
+		t.Errorf("body = %q, want input unchanged", body)
+	}
+}
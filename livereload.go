@@ -0,0 +1,106 @@
+package blog
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// liveReloadScript is injected into rendered pages in Development mode. It
+// opens an SSE connection to /_livereload and reloads the page whenever a
+// message arrives.
+const liveReloadScript = `<script>(function(){` +
+	`var es=new EventSource("/_livereload");` +
+	`es.onmessage=function(){location.reload();};` +
+	`})();</script>`
+
+// liveReload fans reload notifications out to connected /_livereload
+// clients.
+type liveReload struct {
+	mu      sync.Mutex
+	clients map[chan struct{}]bool
+}
+
+// newLiveReload returns an empty liveReload.
+func newLiveReload() *liveReload {
+	return &liveReload{clients: map[chan struct{}]bool{}}
+}
+
+// subscribe registers a new client and returns the channel it will receive
+// reload notifications on.
+func (self *liveReload) subscribe() chan struct{} {
+	ch := make(chan struct{}, 1)
+	self.mu.Lock()
+	self.clients[ch] = true
+	self.mu.Unlock()
+	return ch
+}
+
+// unsubscribe removes and closes a client's channel.
+func (self *liveReload) unsubscribe(ch chan struct{}) {
+	self.mu.Lock()
+	delete(self.clients, ch)
+	self.mu.Unlock()
+	close(ch)
+}
+
+// broadcast notifies every subscribed client to reload.
+func (self *liveReload) broadcast() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	for ch := range self.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// serveLiveReload handles /_livereload, streaming a reload notification to
+// the client as a Server-Sent Event whenever a post, template, or (if
+// WithStaticDir was given) static asset changes.
+func (self *Blog) serveLiveReload(w http.ResponseWriter, r *http.Request) {
+	if !self.Development {
+		http.NotFound(w, r)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := self.liveReload.subscribe()
+	defer self.liveReload.unsubscribe(ch)
+
+	for {
+		select {
+		case <-ch:
+			fmt.Fprint(w, "data: reload\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// injectLiveReload inserts liveReloadScript immediately before the last
+// </body> in html, if any.
+func injectLiveReload(html []byte) []byte {
+	idx := bytes.LastIndex(html, []byte("</body>"))
+	if idx == -1 {
+		return html
+	}
+
+	out := make([]byte, 0, len(html)+len(liveReloadScript))
+	out = append(out, html[:idx]...)
+	out = append(out, []byte(liveReloadScript)...)
+	out = append(out, html[idx:]...)
+	return out
+}
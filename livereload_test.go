@@ -0,0 +1,28 @@
+package blog
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestServeLiveReloadReadsDevelopmentLive guards against a regression where
+// serveLiveReload was registered as a bound method value over a Blog value
+// receiver, freezing Development at its zero value from construction time.
+// Since there is no Option to set Development, toggling the exported field
+// after NewBlog returns must be observed by the handler.
+func TestServeLiveReloadReadsDevelopmentLive(t *testing.T) {
+	blog, router := newTestBlog(t)
+	blog.Development = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/_livereload", nil).WithContext(ctx)
+	router.ServeHTTP(w, r)
+
+	if w.Code == 404 {
+		t.Error("serveLiveReload returned 404 despite Development = true; field change after construction was ignored")
+	}
+}
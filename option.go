@@ -0,0 +1,36 @@
+package blog
+
+import "github.com/yuin/goldmark"
+
+// Option configures optional Blog behavior at construction time, passed to
+// NewBlog or NewBlogSimple.
+type Option func(*Blog)
+
+// WithRenderer overrides the default Goldmark-based Renderer with r.
+func WithRenderer(r Renderer) Option {
+	return func(blog *Blog) {
+		blog.renderer = r
+	}
+}
+
+// WithGoldmarkExtensions registers additional Goldmark extensions on top of
+// the default renderer's GFM, footnote, typographer, and syntax-highlighting
+// extensions. It has no effect if WithRenderer has replaced the renderer.
+func WithGoldmarkExtensions(extensions ...goldmark.Extender) Option {
+	return func(blog *Blog) {
+		if _, ok := blog.renderer.(*goldmarkRenderer); !ok {
+			return
+		}
+		blog.renderer = newGoldmarkRenderer(extensions...)
+	}
+}
+
+// WithStaticDir watches dir for changes in Development mode, notifying
+// live-reload clients when a static asset (CSS, images, ...) served outside
+// of Blog changes. Unset by default, in which case only posts and the
+// template trigger a reload.
+func WithStaticDir(dir string) Option {
+	return func(blog *Blog) {
+		blog.staticDir = dir
+	}
+}
@@ -0,0 +1,45 @@
+package blog
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+)
+
+// blogWithPosts returns a Blog backed by an in-memory cache of n posts,
+// most recent last, suitable for exercising pagination boundary math
+// without a real postDir or router. If tags is non-empty, every post
+// carries it.
+func blogWithPosts(n, pageSize int, tags ...string) *Blog {
+	cache := newPostCache()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		p := post{Name: "post", Date: base.AddDate(0, 0, i), Tags: tags}
+		cache.set(string(rune('a'+i)), sha256.Sum256([]byte{byte(i)}), p)
+	}
+	return &Blog{name: "test", pageSize: pageSize, cache: cache}
+}
+
+func TestGetPageBoundaries(t *testing.T) {
+	blog := blogWithPosts(7, 3)
+
+	cases := []struct {
+		page int
+		want int
+	}{
+		{0, 3}, // full first page
+		{1, 3}, // full second page
+		{2, 1}, // partial last page
+		{3, 0}, // past the end
+	}
+	for _, c := range cases {
+		posts, err := blog.getPage(c.page)
+		if err != nil {
+			t.Fatalf("getPage(%d): %v", c.page, err)
+		}
+		if len(posts) != c.want {
+			t.Errorf("getPage(%d) returned %d posts, want %d", c.page, len(posts), c.want)
+		}
+	}
+}
+
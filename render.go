@@ -0,0 +1,56 @@
+package blog
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+// Renderer converts post Markdown into rendered HTML. Blog uses
+// newGoldmarkRenderer by default, but callers may supply their own via
+// WithRenderer.
+type Renderer interface {
+	// Render converts markdown to HTML.
+	Render(markdown []byte) (template.HTML, error)
+}
+
+// goldmarkRenderer is the default Renderer, wrapping a configured
+// goldmark.Markdown instance.
+type goldmarkRenderer struct {
+	md goldmark.Markdown
+}
+
+// newGoldmarkRenderer returns a goldmarkRenderer configured with GFM
+// (tables, strikethrough, etc.), footnotes, typographer smart punctuation,
+// and Chroma-based syntax highlighting for fenced code blocks. extra
+// Goldmark extensions, if any, are registered in addition to the defaults.
+func newGoldmarkRenderer(extra ...goldmark.Extender) *goldmarkRenderer {
+	exts := append([]goldmark.Extender{
+		extension.GFM,
+		extension.Footnote,
+		extension.Typographer,
+		highlighting.NewHighlighting(
+			highlighting.WithStyle("monokai"),
+		),
+	}, extra...)
+
+	md := goldmark.New(
+		goldmark.WithExtensions(exts...),
+		goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+	)
+
+	return &goldmarkRenderer{md: md}
+}
+
+// Render implements Renderer.
+func (self *goldmarkRenderer) Render(markdown []byte) (template.HTML, error) {
+	var buf bytes.Buffer
+	if err := self.md.Convert(markdown, &buf); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
@@ -0,0 +1,50 @@
+package blog
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestTagPageRouteIsReachable guards against a regression where
+// getTagPage's pagination math was correct but unreachable over HTTP: no
+// route ever passed a non-zero page to it, so only page 0 of any tag could
+// ever be served.
+func TestTagPageRouteIsReachable(t *testing.T) {
+	tplDir := t.TempDir()
+	tplPath := filepath.Join(tplDir, "blog.tpl")
+	tpl := "{{.CurrentTag}}:{{range .Posts}}{{.Name}} {{end}}"
+	if err := os.WriteFile(tplPath, []byte(tpl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	postDir := t.TempDir()
+	const pageSize = 2
+	const total = 5
+	for i := 0; i < total; i++ {
+		name := fmt.Sprintf("2024-01-%02d.post%d.md", i+1, i)
+		content := "---\ntags: [a]\n---\nbody\n"
+		if err := os.WriteFile(filepath.Join(postDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router := mux.NewRouter()
+	NewBlog("test", router, tplPath, postDir+string(os.PathSeparator), pageSize)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/tag/a/page/1/", nil)
+	router.ServeHTTP(w, r)
+
+	if w.Code != 200 {
+		t.Fatalf("GET /tag/a/page/1/ status = %d, body = %q", w.Code, w.Body.String())
+	}
+	// Page 0 holds posts 4 and 3 (most recent first); page 1 holds 2 and 1.
+	if want := "a:post2 post1 "; w.Body.String() != want {
+		t.Errorf("body = %q, want %q", w.Body.String(), want)
+	}
+}
@@ -0,0 +1,36 @@
+package blog
+
+// tagIndex returns the cached map from tag name to the posts carrying that
+// tag, most recent first within each tag, excluding drafts unless
+// Development is set. The index itself is maintained by the post cache and
+// rebuilt whenever the post set changes, rather than recomputed here.
+func (self *Blog) tagIndex() map[string][]post {
+	return self.cache.tags(self.Development)
+}
+
+// getTagPage retrieves one page of posts tagged with tag, most recent
+// first. Pages are numbered from 0.
+func (self *Blog) getTagPage(tag string, page int) ([]post, error) {
+	posts := self.tagIndex()[tag]
+	start := page * self.pageSize
+	if start >= len(posts) {
+		return nil, nil
+	}
+	end := start + self.pageSize
+	if end > len(posts) {
+		end = len(posts)
+	}
+	return posts[start:end], nil
+}
+
+// TagCloud returns the number of posts tagged with each known tag, for use
+// in rendering a tag cloud in templates.
+func (self *Blog) TagCloud() map[string]int {
+	index := self.tagIndex()
+
+	cloud := make(map[string]int, len(index))
+	for tag, posts := range index {
+		cloud[tag] = len(posts)
+	}
+	return cloud
+}
@@ -0,0 +1,73 @@
+package blog
+
+import (
+	"crypto/sha256"
+	"testing"
+)
+
+func TestGetTagPageBoundaries(t *testing.T) {
+	blog := blogWithPosts(5, 2, "a")
+
+	cases := []struct {
+		page int
+		want int
+	}{
+		{0, 2},
+		{1, 2},
+		{2, 1},
+		{3, 0},
+	}
+	for _, c := range cases {
+		posts, err := blog.getTagPage("a", c.page)
+		if err != nil {
+			t.Fatalf("getTagPage(%q, %d): %v", "a", c.page, err)
+		}
+		if len(posts) != c.want {
+			t.Errorf("getTagPage(%q, %d) returned %d posts, want %d", "a", c.page, len(posts), c.want)
+		}
+	}
+
+	if posts, err := blog.getTagPage("unknown", 0); err != nil || len(posts) != 0 {
+		t.Errorf(`getTagPage("unknown", 0) = (%v, %v), want (empty, nil)`, posts, err)
+	}
+}
+
+// TestTagIndexServesFromCache verifies that the tag index maintained by the
+// cache reflects a cache.remove: the cache lazily rebuilds the index from
+// posts the next time it's read after a set/remove.
+func TestTagIndexServesFromCache(t *testing.T) {
+	blog := blogWithPosts(3, 10, "a")
+
+	before := blog.TagCloud()
+	if before["a"] != 3 {
+		t.Fatalf(`TagCloud()["a"] = %d, want 3`, before["a"])
+	}
+
+	blog.cache.remove("a")
+
+	after := blog.TagCloud()
+	if after["a"] != 2 {
+		t.Errorf(`TagCloud()["a"] after remove = %d, want 2 (index should update via cache.remove, not per-call recomputation from a stale source)`, after["a"])
+	}
+}
+
+// TestTagIndexExcludesDraftsUnlessDevelopment verifies the cached index
+// still respects Draft/Development filtering, now applied by the cache
+// rather than by walking allPosts() per request.
+func TestTagIndexExcludesDraftsUnlessDevelopment(t *testing.T) {
+	cache := newPostCache()
+	cache.set("draft", sha256.Sum256([]byte("draft")),
+		post{Name: "draft", Tags: []string{"a"}, Draft: true})
+	cache.set("published", sha256.Sum256([]byte("published")),
+		post{Name: "published", Tags: []string{"a"}})
+
+	blog := &Blog{name: "test", pageSize: 10, cache: cache}
+	if got := blog.TagCloud()["a"]; got != 1 {
+		t.Errorf(`TagCloud()["a"] = %d, want 1 (draft excluded)`, got)
+	}
+
+	blog.Development = true
+	if got := blog.TagCloud()["a"]; got != 2 {
+		t.Errorf(`TagCloud()["a"] = %d, want 2 with Development set`, got)
+	}
+}
@@ -0,0 +1,94 @@
+package blog
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watch starts a goroutine which keeps the post cache fresh as files under
+// postDir are created, modified, or removed, and, in Development mode,
+// reparses the template when tplPath changes and notifies live-reload
+// clients when tplPath or staticDir (if set) changes.
+func (self *Blog) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(self.postDir); err != nil {
+		watcher.Close()
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(self.tplPath)); err != nil {
+		watcher.Close()
+		return err
+	}
+	if self.staticDir != "" {
+		if err := watcher.Add(self.staticDir); err != nil {
+			watcher.Close()
+			return err
+		}
+	}
+	self.watcher = watcher
+
+	go self.watchLoop()
+	return nil
+}
+
+// watchLoop consumes watcher events until the watcher is closed.
+func (self *Blog) watchLoop() {
+	for {
+		select {
+		case event, ok := <-self.watcher.Events:
+			if !ok {
+				return
+			}
+			self.handleWatchEvent(event)
+		case err, ok := <-self.watcher.Errors:
+			if !ok {
+				return
+			}
+			self.Logger.Printf("Error watching %s: %v\n", self.name, err)
+		}
+	}
+}
+
+// handleWatchEvent refreshes whatever cache corresponds to the changed file,
+// then, in Development mode, notifies live-reload clients.
+func (self *Blog) handleWatchEvent(event fsnotify.Event) {
+	if event.Name == self.tplPath {
+		if !self.Development {
+			return
+		}
+		if err := self.tplCache.reload(); err != nil {
+			self.Logger.Printf("Error reloading template %s: %v\n", self.tplPath, err)
+			return
+		}
+		self.liveReload.broadcast()
+		return
+	}
+
+	if self.staticDir != "" && strings.HasPrefix(event.Name, self.staticDir) {
+		if self.Development {
+			self.liveReload.broadcast()
+		}
+		return
+	}
+
+	switch {
+	case event.Op&(fsnotify.Write|fsnotify.Create) != 0:
+		if err := self.loadPost(event.Name); err != nil {
+			self.Logger.Printf("Error loading post %s: %v\n", event.Name, err)
+			return
+		}
+	case event.Op&(fsnotify.Remove|fsnotify.Rename) != 0:
+		self.cache.remove(event.Name)
+	default:
+		return
+	}
+
+	if self.Development {
+		self.liveReload.broadcast()
+	}
+}
@@ -0,0 +1,47 @@
+package blog
+
+import "testing"
+
+// TestHandleWatchEventStaticDirBroadcastsInDevelopment verifies that a
+// change under staticDir triggers a live-reload broadcast in Development
+// mode, and is otherwise ignored, without touching the post cache.
+func TestHandleWatchEventStaticDirBroadcastsInDevelopment(t *testing.T) {
+	blog := &Blog{
+		name:        "test",
+		staticDir:   "/static",
+		Development: true,
+		cache:       newPostCache(),
+		liveReload:  newLiveReload(),
+	}
+
+	ch := blog.liveReload.subscribe()
+	defer blog.liveReload.unsubscribe(ch)
+
+	blog.handleWatchEvent(fakeWriteEvent("/static/style.css"))
+
+	select {
+	case <-ch:
+	default:
+		t.Error("no reload broadcast for a staticDir change in Development mode")
+	}
+}
+
+func TestHandleWatchEventStaticDirIgnoredOutsideDevelopment(t *testing.T) {
+	blog := &Blog{
+		name:       "test",
+		staticDir:  "/static",
+		cache:      newPostCache(),
+		liveReload: newLiveReload(),
+	}
+
+	ch := blog.liveReload.subscribe()
+	defer blog.liveReload.unsubscribe(ch)
+
+	blog.handleWatchEvent(fakeWriteEvent("/static/style.css"))
+
+	select {
+	case <-ch:
+		t.Error("reload broadcast for a staticDir change outside Development mode")
+	default:
+	}
+}